@@ -29,6 +29,7 @@ arglist = [
     "run -f example-score.yaml",
     "run -f example-score.yaml --build test",
     "run -f example-score.yaml --overrides overrides.yaml",
+    "run -f example-score.yaml --profile debug=hello-world",
     "run --verbose",
 ]
 
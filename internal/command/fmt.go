@@ -0,0 +1,208 @@
+/*
+Apache Score
+Copyright 2022 The Apache Software Foundation
+
+This product includes software developed at
+The Apache Software Foundation (http://www.apache.org/).
+*/
+package command
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v3"
+
+	loader "github.com/score-spec/score-go/loader"
+	score "github.com/score-spec/score-go/types"
+)
+
+var (
+	fmtCheck bool
+)
+
+func init() {
+	fmtCmd.Flags().BoolVar(&fmtCheck, "check", false, "Report files which are not formatted instead of rewriting them")
+
+	rootCmd.AddCommand(fmtCmd)
+}
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt [files...]",
+	Short: "Rewrite SCORE files in canonical format",
+	Long: `Rewrites SCORE files with sorted keys and two spaces indentation.
+Top-level keys follow the specification order: apiVersion, metadata, service, containers and resources.
+When no files are given, './score.yaml' is formatted.`,
+	SilenceUsage: true,
+	RunE:         formatFiles,
+}
+
+func formatFiles(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		args = []string{scoreFileDefault}
+	}
+
+	var unformatted = 0
+	for _, file := range args {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		dst, err := formatScore(src)
+		if err != nil {
+			return fmt.Errorf("formatting '%s': %w", file, err)
+		}
+		if bytes.Equal(src, dst) {
+			continue
+		}
+
+		if fmtCheck {
+			fmt.Fprintln(cmd.OutOrStdout(), file)
+			unformatted++
+			continue
+		}
+
+		if err := os.WriteFile(file, dst, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+
+	if unformatted > 0 {
+		return fmt.Errorf("%d file(s) are not formatted", unformatted)
+	}
+	return nil
+}
+
+// formatScore validates the SCORE spec and re-encodes it with sorted keys.
+// Comments are preserved.
+func formatScore(src []byte) ([]byte, error) {
+	var dec = yaml.NewDecoder(bytes.NewReader(src))
+	var doc yaml.Node
+	if err := dec.Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("no SCORE spec found")
+		}
+		return nil, err
+	}
+	// NOTE: Rewriting only the first document would silently drop all the others
+	var next yaml.Node
+	if err := dec.Decode(&next); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New("multiple YAML documents are not supported")
+	}
+	// END (NOTE)
+
+	var srcMap map[string]interface{}
+	if err := doc.Decode(&srcMap); err != nil {
+		return nil, err
+	}
+	var spec score.WorkloadSpec
+	if err := loader.MapSpec(&spec, srcMap); err != nil {
+		return nil, fmt.Errorf("validating workload spec: %w", err)
+	}
+
+	sortKeys(&doc)
+	if err := checkAliases(&doc, map[string]bool{}); err != nil {
+		return nil, fmt.Errorf("sorting keys: %w", err)
+	}
+
+	var buf bytes.Buffer
+	var enc = yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// specKeysOrder lists SCORE spec top-level keys in the order used by the specification
+var specKeysOrder = []string{"apiVersion", "metadata", "service", "containers", "resources"}
+
+// sortKeys sorts SCORE spec top-level keys in the specification order and nested mapping keys by name
+func sortKeys(doc *yaml.Node) {
+	for _, root := range doc.Content {
+		sortMapping(root, func(a, b string) bool {
+			var rankA, rankB = specKeyRank(a), specKeyRank(b)
+			if rankA != rankB {
+				return rankA < rankB
+			}
+			return a < b
+		})
+		for _, child := range root.Content {
+			sortNestedKeys(child)
+		}
+	}
+}
+
+// sortNestedKeys sorts all mapping keys within the YAML node recursively
+func sortNestedKeys(node *yaml.Node) {
+	sortMapping(node, func(a, b string) bool {
+		return a < b
+	})
+
+	for _, child := range node.Content {
+		sortNestedKeys(child)
+	}
+}
+
+// sortMapping reorders the YAML mapping node key-value pairs
+func sortMapping(node *yaml.Node, less func(a, b string) bool) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	var pairs = make([][2]*yaml.Node, 0, len(node.Content)/2)
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		pairs = append(pairs, [2]*yaml.Node{node.Content[idx], node.Content[idx+1]})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return less(pairs[i][0].Value, pairs[j][0].Value)
+	})
+	for idx, pair := range pairs {
+		node.Content[2*idx] = pair[0]
+		node.Content[2*idx+1] = pair[1]
+	}
+}
+
+// specKeyRank returns the position of the top-level key in the specification order.
+// Unknown keys are placed after all known ones.
+func specKeyRank(key string) int {
+	for idx, known := range specKeysOrder {
+		if key == known {
+			return idx
+		}
+	}
+	return len(specKeysOrder)
+}
+
+// checkAliases makes sure all aliases within the YAML node refer to anchors defined earlier in the document
+func checkAliases(node *yaml.Node, anchors map[string]bool) error {
+	if node.Kind == yaml.AliasNode && !anchors[node.Value] {
+		return fmt.Errorf("alias '*%s' would be placed before its anchor", node.Value)
+	}
+	if node.Anchor != "" {
+		anchors[node.Anchor] = true
+	}
+
+	for _, child := range node.Content {
+		if err := checkAliases(child, anchors); err != nil {
+			return err
+		}
+	}
+	return nil
+}
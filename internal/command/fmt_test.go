@@ -0,0 +1,228 @@
+/*
+Apache Score
+Copyright 2022 The Apache Software Foundation
+
+This product includes software developed at
+The Apache Software Foundation (http://www.apache.org/).
+*/
+package command
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+func TestFormatScore(t *testing.T) {
+	var tests = []struct {
+		Name   string
+		Source []byte
+		Output []byte
+		Error  error
+	}{
+		// Success path
+		//
+		{
+			Name: "Should sort keys and fix indentation",
+			Source: []byte(`metadata:
+    name: hello-world
+apiVersion: score.dev/v1b1
+containers:
+    hello:
+        image: busybox
+        command: ["/bin/sh"]
+        # Keep printing
+        args:
+        - -c
+        - while true; do echo Hello World!; sleep 5; done
+`),
+			Output: []byte(`apiVersion: score.dev/v1b1
+metadata:
+  name: hello-world
+containers:
+  hello:
+    # Keep printing
+    args:
+      - -c
+      - while true; do echo Hello World!; sleep 5; done
+    command: ["/bin/sh"]
+    image: busybox
+`),
+		},
+		{
+			Name: "Should keep formatted spec as is",
+			Source: []byte(`apiVersion: score.dev/v1b1
+metadata:
+  name: hello-world
+service:
+  ports:
+    www:
+      port: 80
+      targetPort: 8080
+containers:
+  hello:
+    image: busybox
+resources:
+  db:
+    type: postgres
+`),
+			Output: []byte(`apiVersion: score.dev/v1b1
+metadata:
+  name: hello-world
+service:
+  ports:
+    www:
+      port: 80
+      targetPort: 8080
+containers:
+  hello:
+    image: busybox
+resources:
+  db:
+    type: postgres
+`),
+		},
+		{
+			Name: "Should sort top-level keys in specification order",
+			Source: []byte(`resources:
+  db:
+    type: postgres
+containers:
+  hello:
+    image: busybox
+service:
+  ports:
+    www:
+      targetPort: 8080
+      port: 80
+metadata:
+  name: hello-world
+apiVersion: score.dev/v1b1
+`),
+			Output: []byte(`apiVersion: score.dev/v1b1
+metadata:
+  name: hello-world
+service:
+  ports:
+    www:
+      port: 80
+      targetPort: 8080
+containers:
+  hello:
+    image: busybox
+resources:
+  db:
+    type: postgres
+`),
+		},
+		{
+			Name: "Should keep aliases after their anchors",
+			Source: []byte(`metadata:
+  name: hello-world
+containers:
+  hello:
+    image: busybox
+    variables: &vars
+      LOGS_LEVEL: DEBUG
+  world:
+    image: busybox
+    variables: *vars
+`),
+			Output: []byte(`metadata:
+  name: hello-world
+containers:
+  hello:
+    image: busybox
+    variables: &vars
+      LOGS_LEVEL: DEBUG
+  world:
+    image: busybox
+    variables: *vars
+`),
+		},
+
+		// Errors handling
+		//
+		{
+			Name:   "Should report an error for empty spec",
+			Source: []byte(``),
+			Error:  errors.New("no SCORE spec found"),
+		},
+		{
+			Name: "Should report an error for multiple documents",
+			Source: []byte(`metadata:
+  name: hello
+---
+metadata:
+  name: world
+`),
+			Error: errors.New("multiple YAML documents are not supported"),
+		},
+		{
+			Name: "Should report an error for invalid spec",
+			Source: []byte(`metadata:
+  name: [hello, world]
+`),
+			Error: errors.New("validating workload spec"),
+		},
+		{
+			Name: "Should report an error when sorting moves an alias before its anchor",
+			Source: []byte(`metadata:
+  name: hello-world
+containers:
+  hello:
+    variables:
+      B: &anc foo
+      A: *anc
+    image: busybox
+`),
+			Error: errors.New("sorting keys: alias '*anc' would be placed before its anchor"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			output, err := formatScore(tt.Source)
+
+			if tt.Error != nil {
+				// On Error
+				//
+				assert.ErrorContains(t, err, tt.Error.Error())
+			} else {
+				// On Success
+				//
+				assert.NoError(t, err)
+				assert.Equal(t, string(tt.Output), string(output))
+			}
+		})
+	}
+}
+
+func TestFormatFilesCheck(t *testing.T) {
+	var dir = t.TempDir()
+	var formatted = filepath.Join(dir, "formatted.yaml")
+	var unformatted = filepath.Join(dir, "unformatted.yaml")
+	assert.NoError(t, os.WriteFile(formatted, []byte("metadata:\n  name: a\n"), 0644))
+	assert.NoError(t, os.WriteFile(unformatted, []byte("metadata:\n    name: b\n"), 0644))
+
+	defer func() { fmtCheck = false }()
+	fmtCheck = true
+
+	var out bytes.Buffer
+	fmtCmd.SetOut(&out)
+	err := formatFiles(fmtCmd, []string{formatted, unformatted})
+
+	assert.EqualError(t, err, "1 file(s) are not formatted")
+	assert.Equal(t, unformatted+"\n", out.String())
+	content, _ := os.ReadFile(unformatted)
+	assert.Equal(t, "metadata:\n    name: b\n", string(content))
+
+	fmtCheck = false
+	assert.NoError(t, formatFiles(fmtCmd, []string{formatted, unformatted}))
+	content, _ = os.ReadFile(unformatted)
+	assert.Equal(t, "metadata:\n  name: b\n", string(content))
+}
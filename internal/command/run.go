@@ -8,11 +8,13 @@ The Apache Software Foundation (http://www.apache.org/).
 package command
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
-	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/compose-spec/compose-go/types"
 	"github.com/imdario/mergo"
@@ -30,21 +32,39 @@ const (
 )
 
 var (
-	scoreFile     string
-	overridesFile string
-	outFile       string
-	envFile       string
-	buildCtx      string
+	scoreFile      string
+	overridesFiles []string
+	outFile        string
+	outPerm        string
+	envFile        string
+	outFormat      string
+	overrideFile   string
+	buildCtx       string
+	overlayFile    string
+	profiles       []string
+	probeTool      string
 
-	verbose bool
+	envFileDefaults bool
+	explainEnv      bool
+	pinDigests      bool
+	verbose         bool
 )
 
 func init() {
-	runCmd.Flags().StringVarP(&scoreFile, "file", "f", scoreFileDefault, "Source SCORE file")
-	runCmd.Flags().StringVar(&overridesFile, "overrides", overridesFileDefault, "Overrides SCORE file")
+	runCmd.Flags().StringVarP(&scoreFile, "file", "f", scoreFileDefault, "Source SCORE file ('-' to read from STDIN)")
+	runCmd.Flags().StringArrayVar(&overridesFiles, "overrides", []string{overridesFileDefault}, "Overrides SCORE files (applied in order)")
 	runCmd.Flags().StringVarP(&outFile, "output", "o", "", "Output file")
-	runCmd.Flags().StringVar(&envFile, "env-file", "", "Location to store sample .env file")
+	runCmd.Flags().StringVar(&outPerm, "output-permissions", "", "Permissions in octal notation (e.g. 0600) applied to all output files (--output, --override-output and --env-file)")
+	runCmd.Flags().StringVar(&overrideFile, "override-output", "", "Output file for local settings (published ports, build instructions and bind mounts)")
+	runCmd.Flags().StringVar(&outFormat, "format", "yaml", "Output format (yaml or json)")
+	runCmd.Flags().StringVar(&envFile, "env-file", "", "Location to store sample .env file ('-' to write to STDERR)")
+	runCmd.Flags().BoolVar(&envFileDefaults, "env-file-defaults", false, "Skip variables without default values in .env file")
 	runCmd.Flags().StringVar(&buildCtx, "build", "", "Replaces 'image' name with compose 'build' instruction")
+	runCmd.Flags().StringVar(&overlayFile, "merge-overlay", "", "Merges docker-compose configuration file over the output")
+	runCmd.Flags().StringArrayVar(&profiles, "profile", nil, "Assigns compose profile to the workload service (PROFILE=WORKLOAD)")
+	runCmd.Flags().StringVar(&probeTool, "probe-tool", compose.ProbeToolWget, "Command line tool used by healthchecks to call HTTP probes (wget or curl)")
+	runCmd.Flags().BoolVar(&explainEnv, "explain-env", false, "Explain origins of containers environment variables (written to STDERR)")
+	runCmd.Flags().BoolVar(&pinDigests, "pin-digests", false, "Pins images tags to their current digests (requires docker)")
 
 	runCmd.Flags().BoolVar(&verbose, "verbose", false, "Enable diagnostic messages (written to STDERR)")
 
@@ -62,15 +82,37 @@ func run(cmd *cobra.Command, args []string) error {
 		log.SetOutput(io.Discard)
 	}
 
+	var err error
+	if outFormat != "yaml" && outFormat != "json" {
+		return fmt.Errorf("unsupported output format '%s'", outFormat)
+	}
+	if probeTool != compose.ProbeToolWget && probeTool != compose.ProbeToolCurl {
+		return fmt.Errorf("unsupported probe tool '%s'", probeTool)
+	}
+	if outPerm != "" {
+		if _, err = parseFileMode(outPerm); err != nil {
+			return err
+		}
+		if outFile == "" && overrideFile == "" && (envFile == "" || envFile == "-") {
+			return errors.New("output file permissions require an output file (--output, --override-output or --env-file)")
+		}
+	}
+
 	// Open source file
 	//
-	log.Printf("Reading '%s'...\n", scoreFile)
-	var err error
-	var src *os.File
-	if src, err = os.Open(scoreFile); err != nil {
-		return err
+	var src = cmd.InOrStdin()
+	if scoreFile == "-" {
+		log.Print("Reading STDIN...\n")
+	} else {
+		log.Printf("Reading '%s'...\n", scoreFile)
+		srcFile, err := os.Open(scoreFile)
+		if err != nil {
+			return err
+		}
+		defer srcFile.Close()
+
+		src = srcFile
 	}
-	defer src.Close()
 
 	// Parse SCORE spec
 	//
@@ -82,22 +124,8 @@ func run(cmd *cobra.Command, args []string) error {
 
 	// Apply overrides (optional)
 	//
-	if overridesFile != "" {
-		log.Printf("Checking '%s'...\n", overridesFile)
-		if ovr, err := os.Open(overridesFile); err == nil {
-			defer ovr.Close()
-
-			log.Print("Applying SCORE overrides...\n")
-			var ovrMap map[string]interface{}
-			if err = loader.ParseYAML(&ovrMap, ovr); err != nil {
-				return err
-			}
-			if err := mergo.MergeWithOverwrite(&srcMap, ovrMap); err != nil {
-				return fmt.Errorf("applying overrides fom '%s': %w", overridesFile, err)
-			}
-		} else if !os.IsNotExist(err) || overridesFile != overridesFileDefault {
-			return err
-		}
+	if err = applyOverrides(&srcMap, overridesFiles); err != nil {
+		return err
 	}
 
 	// Validate SCORE spec
@@ -111,11 +139,23 @@ func run(cmd *cobra.Command, args []string) error {
 	// Build docker-compose configuration
 	//
 	log.Print("Building docker-compose configuration...\n")
-	proj, vars, err := compose.ConvertSpec(&spec)
+	proj, vars, err := compose.ConvertSpec(&spec, probeTool)
 	if err != nil {
 		return fmt.Errorf("building docker-compose configuration: %w", err)
 	}
 
+	// Explain environment variables (optional)
+	//
+	if explainEnv {
+		explanations, err := compose.ExplainEnv(&spec)
+		if err != nil {
+			return fmt.Errorf("explaining environment variables: %w", err)
+		}
+		for _, item := range explanations {
+			fmt.Fprintf(cmd.ErrOrStderr(), "%s/%s=%s (%s)\n", item.Container, item.Name, item.Value, strings.Join(item.Origins, ", "))
+		}
+	}
+
 	// Override 'image' reference with 'build' instructions
 	//
 	if buildCtx != "" {
@@ -123,17 +163,76 @@ func run(cmd *cobra.Command, args []string) error {
 		for idx := range proj.Services {
 			if proj.Services[idx].Name == spec.Metadata.Name {
 				proj.Services[idx].Build = &types.BuildConfig{Context: buildCtx}
-				proj.Services[idx].Image = ""
+				// NOTE: The base file must remain usable on its own when build instructions go into the override file.
+				if overrideFile == "" {
+					proj.Services[idx].Image = ""
+				}
+			}
+		}
+	}
+
+	// Assign compose profiles (optional)
+	//
+	for _, profile := range profiles {
+		log.Printf("Applying profile: '%s'...\n", profile)
+		name, workload, ok := strings.Cut(profile, "=")
+		if !ok || name == "" || workload == "" {
+			return fmt.Errorf("invalid profile '%s': expected PROFILE=WORKLOAD format", profile)
+		}
+		if workload != spec.Metadata.Name {
+			return fmt.Errorf("applying profile '%s': workload '%s' not found", name, workload)
+		}
+		for idx := range proj.Services {
+			if proj.Services[idx].Name == workload {
+				proj.Services[idx].Profiles = append(proj.Services[idx].Profiles, name)
 			}
 		}
 	}
 
+	// Merge docker-compose overlay (optional)
+	//
+	if overlayFile != "" {
+		log.Printf("Merging '%s'...\n", overlayFile)
+		overlay, err := os.ReadFile(overlayFile)
+		if err != nil {
+			return err
+		}
+		if proj, err = compose.MergeOverlay(proj, overlayFile, overlay); err != nil {
+			return fmt.Errorf("merging overlay from '%s': %w", overlayFile, err)
+		}
+	}
+
+	// Pin images digests (optional)
+	//
+	if pinDigests {
+		log.Print("Pinning images digests...\n")
+		if err := compose.PinImageDigests(proj); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: Can not pin images digests: %v\n", err)
+		}
+	}
+
+	// Split local settings into override file (optional)
+	//
+	if overrideFile != "" {
+		log.Printf("Creating '%s'...\n", overrideFile)
+		destFile, err := createFile(overrideFile)
+		if err != nil {
+			return err
+		}
+		defer destFile.Close()
+
+		log.Print("Writing docker-compose override configuration...\n")
+		if err = writeProject(destFile, compose.SplitOverride(proj)); err != nil {
+			return err
+		}
+	}
+
 	// Open output file (optional)
 	//
-	var dest = io.Writer(os.Stdout)
+	var dest = cmd.OutOrStdout()
 	if outFile != "" {
 		log.Printf("Creating '%s'...\n", outFile)
-		destFile, err := os.Create(outFile)
+		destFile, err := createFile(outFile)
 		if err != nil {
 			return err
 		}
@@ -145,38 +244,98 @@ func run(cmd *cobra.Command, args []string) error {
 	// Write docker-compose spec
 	//
 	log.Print("Writing docker-compose configuration...\n")
-	if err = compose.WriteYAML(dest, proj); err != nil {
+	if err = writeProject(dest, proj); err != nil {
 		return err
 	}
 
 	if envFile != "" {
 		// Open .env file
 		//
-		log.Printf("Creating '%s'...\n", envFile)
-		dest, err := os.Create(envFile)
-		if err != nil {
-			return err
+		var dest = cmd.ErrOrStderr()
+		if envFile != "-" {
+			log.Printf("Creating '%s'...\n", envFile)
+			destFile, err := createFile(envFile)
+			if err != nil {
+				return err
+			}
+			defer destFile.Close()
+
+			dest = destFile
 		}
-		defer dest.Close()
 
 		// Write .env file
 		//
 		log.Print("Writing .env file template...\n")
+		if err = compose.WriteEnv(dest, vars, envFileDefaults); err != nil {
+			return err
+		}
+	}
 
-		envVars := make([]string, 0, len(vars))
-		for key, val := range vars {
-			if val == nil {
-				val = ""
-			}
-			var envVar = fmt.Sprintf("%s=%v\n", key, val)
-			envVars = append(envVars, envVar)
+	return nil
+}
+
+// writeProject exports docker-compose specification in the selected output format
+func writeProject(w io.Writer, proj *types.Project) error {
+	switch outFormat {
+	case "json":
+		return compose.WriteJSON(w, proj)
+	default:
+		return compose.WriteYAML(w, proj)
+	}
+}
+
+// createFile creates the output file with the permissions set by '--output-permissions' flag (if any)
+func createFile(name string) (*os.File, error) {
+	destFile, err := os.Create(name)
+	if err != nil || outPerm == "" {
+		return destFile, err
+	}
+
+	mode, err := parseFileMode(outPerm)
+	if err == nil {
+		err = destFile.Chmod(mode)
+	}
+	if err != nil {
+		destFile.Close()
+		return nil, err
+	}
+	return destFile, nil
+}
+
+// parseFileMode parses file permissions in octal notation
+func parseFileMode(perm string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(perm, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("invalid file permissions '%s': expected octal value between 0000 and 0777", perm)
+	}
+	return os.FileMode(mode), nil
+}
+
+// applyOverrides merges overrides files into the source SCORE spec one by one.
+// Later files take precedence over earlier ones. Missing default overrides file is ignored.
+func applyOverrides(srcMap *map[string]interface{}, files []string) error {
+	for _, file := range files {
+		if file == "" {
+			continue
 		}
-		sort.Strings(envVars)
 
-		for _, envVar := range envVars {
-			if _, err := dest.WriteString(envVar); err != nil {
-				return err
+		log.Printf("Checking '%s'...\n", file)
+		ovr, err := os.Open(file)
+		if err != nil {
+			if os.IsNotExist(err) && file == overridesFileDefault {
+				continue
 			}
+			return err
+		}
+		defer ovr.Close()
+
+		log.Printf("Applying SCORE overrides from '%s'...\n", file)
+		var ovrMap map[string]interface{}
+		if err = loader.ParseYAML(&ovrMap, ovr); err != nil {
+			return err
+		}
+		if err := mergo.MergeWithOverwrite(srcMap, ovrMap); err != nil {
+			return fmt.Errorf("applying overrides fom '%s': %w", file, err)
 		}
 	}
 
@@ -0,0 +1,266 @@
+/*
+Apache Score
+Copyright 2022 The Apache Software Foundation
+
+This product includes software developed at
+The Apache Software Foundation (http://www.apache.org/).
+*/
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/score-spec/score-compose/internal/compose"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+func TestApplyOverrides(t *testing.T) {
+	var dir = t.TempDir()
+	var base = filepath.Join(dir, "base.yaml")
+	var prod = filepath.Join(dir, "prod.yaml")
+	assert.NoError(t, os.WriteFile(base, []byte(`containers:
+  hello:
+    image: nginx:base
+    variables:
+      LOGS_LEVEL: DEBUG
+      REGION: eu
+`), 0644))
+	assert.NoError(t, os.WriteFile(prod, []byte(`containers:
+  hello:
+    image: nginx:prod
+    variables:
+      LOGS_LEVEL: WARN
+`), 0644))
+
+	var srcMap = map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": "hello-world",
+		},
+		"containers": map[string]interface{}{
+			"hello": map[string]interface{}{
+				"image": "busybox",
+			},
+		},
+	}
+
+	err := applyOverrides(&srcMap, []string{base, prod})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": "hello-world",
+		},
+		"containers": map[string]interface{}{
+			"hello": map[string]interface{}{
+				"image": "nginx:prod",
+				"variables": map[string]interface{}{
+					"LOGS_LEVEL": "WARN",
+					"REGION":     "eu",
+				},
+			},
+		},
+	}, srcMap)
+}
+
+func TestApplyOverridesMissingFile(t *testing.T) {
+	var srcMap = map[string]interface{}{}
+
+	assert.NoError(t, applyOverrides(&srcMap, []string{overridesFileDefault}))
+	assert.ErrorIs(t, applyOverrides(&srcMap, []string{filepath.Join(t.TempDir(), "missing.yaml")}), os.ErrNotExist)
+}
+
+func TestParseFileMode(t *testing.T) {
+	mode, err := parseFileMode("0600")
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), mode)
+
+	mode, err = parseFileMode("755")
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), mode)
+
+	_, err = parseFileMode("0999")
+	assert.ErrorContains(t, err, "invalid file permissions '0999'")
+	_, err = parseFileMode("1777")
+	assert.ErrorContains(t, err, "invalid file permissions '1777'")
+	_, err = parseFileMode("rw-------")
+	assert.ErrorContains(t, err, "invalid file permissions 'rw-------'")
+}
+
+// helloWorldScore is the SCORE spec used by run command tests
+const helloWorldScore = `metadata:
+  name: hello-world
+containers:
+  hello:
+    image: busybox
+`
+
+// setupRun writes the SCORE spec for the run command and captures the command output.
+// All run flags are reset to their defaults when the test is over.
+func setupRun(t *testing.T, spec string) (stdout *bytes.Buffer, stderr *bytes.Buffer) {
+	var src = filepath.Join(t.TempDir(), "score.yaml")
+	assert.NoError(t, os.WriteFile(src, []byte(spec), 0644))
+
+	t.Cleanup(func() {
+		scoreFile = scoreFileDefault
+		overridesFiles = []string{overridesFileDefault}
+		outFile = ""
+		outPerm = ""
+		envFile = ""
+		outFormat = "yaml"
+		overrideFile = ""
+		buildCtx = ""
+		overlayFile = ""
+		profiles = nil
+		probeTool = compose.ProbeToolWget
+
+		envFileDefaults = false
+		explainEnv = false
+		pinDigests = false
+		verbose = false
+
+		runCmd.SetIn(nil)
+		runCmd.SetOut(nil)
+		runCmd.SetErr(nil)
+	})
+	scoreFile = src
+	stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}
+	runCmd.SetOut(stdout)
+	runCmd.SetErr(stderr)
+
+	return stdout, stderr
+}
+
+func TestRunOutputPermissions(t *testing.T) {
+	stdout, _ := setupRun(t, helloWorldScore)
+	var dir = t.TempDir()
+	outFile = filepath.Join(dir, "compose.yaml")
+	overrideFile = filepath.Join(dir, "compose.override.yaml")
+	envFile = filepath.Join(dir, ".env")
+	outPerm = "0600"
+
+	assert.NoError(t, run(runCmd, nil))
+
+	for _, file := range []string{outFile, overrideFile, envFile} {
+		info, err := os.Stat(file)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), file)
+	}
+	content, err := os.ReadFile(outFile)
+	assert.NoError(t, err)
+	assert.Equal(t, stdout.String(), string(content))
+}
+
+func TestRunInvalidOutputPermissions(t *testing.T) {
+	setupRun(t, helloWorldScore)
+	outPerm = "abc"
+
+	assert.EqualError(t, run(runCmd, nil), "invalid file permissions 'abc': expected octal value between 0000 and 0777")
+
+	outPerm = "0600"
+	envFile = "-"
+
+	assert.EqualError(t, run(runCmd, nil), "output file permissions require an output file (--output, --override-output or --env-file)")
+}
+
+func TestRunEnvFileToStderr(t *testing.T) {
+	stdout, stderr := setupRun(t, helloWorldScore+`    variables:
+      FRIEND: ${resources.env.NAME}
+resources:
+  env:
+    type: environment
+    properties:
+      NAME:
+        default: World
+`)
+	envFile = "-"
+
+	assert.NoError(t, run(runCmd, nil))
+
+	assert.Contains(t, stdout.String(), "FRIEND: ${NAME-World}")
+	assert.Equal(t, "NAME=World\n", stderr.String())
+}
+
+func TestRunPinDigestsWarning(t *testing.T) {
+	stdout, stderr := setupRun(t, helloWorldScore)
+	pinDigests = true
+
+	// NOTE: Docker CLI can't be found with an empty PATH, so digests can't be resolved
+	t.Setenv("PATH", t.TempDir())
+
+	assert.NoError(t, run(runCmd, nil))
+
+	assert.Contains(t, stdout.String(), "image: busybox")
+	assert.Contains(t, stderr.String(), "Warning: Can not pin images digests: resolving digest for 'busybox:latest'")
+}
+
+func TestRunProfileErrors(t *testing.T) {
+	setupRun(t, helloWorldScore)
+
+	profiles = []string{"debug"}
+	assert.EqualError(t, run(runCmd, nil), "invalid profile 'debug': expected PROFILE=WORKLOAD format")
+
+	profiles = []string{"=hello-world"}
+	assert.EqualError(t, run(runCmd, nil), "invalid profile '=hello-world': expected PROFILE=WORKLOAD format")
+
+	profiles = []string{"debug=unknown"}
+	assert.EqualError(t, run(runCmd, nil), "applying profile 'debug': workload 'unknown' not found")
+}
+
+func TestRunUnsupportedProbeTool(t *testing.T) {
+	setupRun(t, helloWorldScore)
+	probeTool = "httpie"
+
+	assert.EqualError(t, run(runCmd, nil), "unsupported probe tool 'httpie'")
+}
+
+func TestRunFromStdin(t *testing.T) {
+	stdout, _ := setupRun(t, "")
+	var dest = filepath.Join(t.TempDir(), "compose.yaml")
+	scoreFile = "-"
+	outFile = dest
+	runCmd.SetIn(bytes.NewBufferString(helloWorldScore))
+
+	assert.NoError(t, run(runCmd, nil))
+
+	content, err := os.ReadFile(dest)
+	assert.NoError(t, err)
+	assert.Equal(t, `services:
+  hello-world:
+    image: busybox
+`, string(content))
+	assert.Equal(t, string(content), stdout.String())
+}
+
+func TestRunMergeOverlayPinDigests(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake docker CLI is a shell script")
+	}
+
+	stdout, stderr := setupRun(t, helloWorldScore)
+	var dir = t.TempDir()
+	overlayFile = filepath.Join(dir, "overlay.yaml")
+	assert.NoError(t, os.WriteFile(overlayFile, []byte(`services:
+  hello-world:
+    image: busybox:1.36
+  sidecar:
+    image: nginx
+`), 0644))
+	pinDigests = true
+
+	// NOTE: Fake docker CLI resolves all images to the same digest
+	var digest = "sha256:" + strings.Repeat("1", 64)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "docker"), []byte("#!/bin/sh\necho "+digest+"\n"), 0755))
+	t.Setenv("PATH", dir)
+
+	assert.NoError(t, run(runCmd, nil))
+
+	assert.Empty(t, stderr.String())
+	assert.Contains(t, stdout.String(), "image: docker.io/library/busybox:1.36@"+digest+"\n")
+	assert.Contains(t, stdout.String(), "image: docker.io/library/nginx:latest@"+digest+"\n")
+}
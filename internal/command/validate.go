@@ -0,0 +1,118 @@
+/*
+Apache Score
+Copyright 2022 The Apache Software Foundation
+
+This product includes software developed at
+The Apache Software Foundation (http://www.apache.org/).
+*/
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/score-spec/score-compose/internal/compose"
+
+	loader "github.com/score-spec/score-go/loader"
+	score "github.com/score-spec/score-go/types"
+)
+
+var (
+	validateFormat string
+)
+
+func init() {
+	validateCmd.Flags().StringVar(&validateFormat, "format", "text", "Results format (text or json)")
+
+	rootCmd.AddCommand(validateCmd)
+}
+
+// validationResult is a machine-readable validation result for a single SCORE file
+type validationResult struct {
+	Path   string   `json:"path"`
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors"`
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [files...]",
+	Short: "Validate SCORE files without producing docker-compose configuration",
+	Long: `Validates SCORE files and reports errors for each of them.
+When no files are given, './score.yaml' is validated.`,
+	SilenceUsage: true,
+	RunE:         validateFiles,
+}
+
+func validateFiles(cmd *cobra.Command, args []string) error {
+	if validateFormat != "text" && validateFormat != "json" {
+		return fmt.Errorf("unsupported results format '%s'", validateFormat)
+	}
+	if len(args) == 0 {
+		args = []string{scoreFileDefault}
+	}
+
+	// NOTE: Conversion diagnostic messages are reported as validation errors instead
+	log.SetOutput(io.Discard)
+
+	var invalid = 0
+	var results = make([]validationResult, 0, len(args))
+	for _, file := range args {
+		var result = validationResult{Path: file, Valid: true, Errors: []string{}}
+		for _, err := range validateFile(file) {
+			result.Valid = false
+			result.Errors = append(result.Errors, err.Error())
+
+			if validateFormat == "text" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s: %v\n", file, err)
+			}
+		}
+		if !result.Valid {
+			invalid++
+		}
+		results = append(results, result)
+	}
+
+	if validateFormat == "json" {
+		var enc = json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return err
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d file(s) are not valid", invalid, len(args))
+	}
+	return nil
+}
+
+// validateFile checks the SCORE file can be parsed and converted into docker-compose configuration.
+// All '${...}' references within the SCORE spec must be resolvable.
+func validateFile(file string) []error {
+	src, err := os.Open(file)
+	if err != nil {
+		return []error{err}
+	}
+	defer src.Close()
+
+	var srcMap map[string]interface{}
+	if err := loader.ParseYAML(&srcMap, src); err != nil {
+		return []error{fmt.Errorf("parsing SCORE spec: %w", err)}
+	}
+
+	var spec score.WorkloadSpec
+	if err := loader.MapSpec(&spec, srcMap); err != nil {
+		return []error{fmt.Errorf("validating workload spec: %w", err)}
+	}
+
+	if _, _, err := compose.ConvertSpec(&spec, compose.ProbeToolWget); err != nil {
+		return []error{fmt.Errorf("building docker-compose configuration: %w", err)}
+	}
+
+	return compose.CheckReferences(&spec)
+}
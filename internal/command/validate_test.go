@@ -0,0 +1,126 @@
+/*
+Apache Score
+Copyright 2022 The Apache Software Foundation
+
+This product includes software developed at
+The Apache Software Foundation (http://www.apache.org/).
+*/
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+func TestValidateFiles(t *testing.T) {
+	var dir = t.TempDir()
+	var valid = filepath.Join(dir, "valid.yaml")
+	var invalid = filepath.Join(dir, "invalid.yaml")
+	var noContainers = filepath.Join(dir, "no-containers.yaml")
+	var missing = filepath.Join(dir, "missing.yaml")
+	var unresolved = filepath.Join(dir, "unresolved.yaml")
+	assert.NoError(t, os.WriteFile(valid, []byte(`metadata:
+  name: hello-world
+containers:
+  hello:
+    image: busybox
+`), 0644))
+	assert.NoError(t, os.WriteFile(invalid, []byte(`metadata:
+  name: [hello, world]
+`), 0644))
+	assert.NoError(t, os.WriteFile(noContainers, []byte(`metadata:
+  name: hello-world
+`), 0644))
+	assert.NoError(t, os.WriteFile(unresolved, []byte(`metadata:
+  name: hello-world
+containers:
+  hello:
+    image: busybox
+    variables:
+      HOST: ${resources.nope.host}
+`), 0644))
+
+	var stderr bytes.Buffer
+	validateCmd.SetErr(&stderr)
+	defer validateCmd.SetErr(nil)
+
+	assert.NoError(t, validateFiles(validateCmd, []string{valid}))
+	assert.Empty(t, stderr.String())
+
+	err := validateFiles(validateCmd, []string{valid, invalid, noContainers, missing, unresolved})
+
+	assert.EqualError(t, err, "4 of 5 file(s) are not valid")
+	assert.Contains(t, stderr.String(), invalid+": validating workload spec")
+	assert.Contains(t, stderr.String(), noContainers+": building docker-compose configuration")
+	assert.Contains(t, stderr.String(), missing+": open")
+	assert.Contains(t, stderr.String(), unresolved+": containers.hello.variables.HOST: can't resolve 'resources.nope.host': resource or property is not declared\n")
+	assert.NotContains(t, stderr.String(), "Warning")
+	assert.NotContains(t, stderr.String(), valid)
+}
+
+func TestValidateFilesJSON(t *testing.T) {
+	var dir = t.TempDir()
+	var valid = filepath.Join(dir, "valid.yaml")
+	var noContainers = filepath.Join(dir, "no-containers.yaml")
+	var unresolved = filepath.Join(dir, "unresolved.yaml")
+	assert.NoError(t, os.WriteFile(valid, []byte(`metadata:
+  name: hello-world
+containers:
+  hello:
+    image: busybox
+`), 0644))
+	assert.NoError(t, os.WriteFile(noContainers, []byte(`metadata:
+  name: hello-world
+`), 0644))
+	assert.NoError(t, os.WriteFile(unresolved, []byte(`metadata:
+  name: hello-world
+containers:
+  hello:
+    image: busybox
+    variables:
+      HOST: ${resources.nope.host}
+      PORT: ${resources.nope.port}
+`), 0644))
+
+	var stdout, stderr bytes.Buffer
+	validateCmd.SetOut(&stdout)
+	validateCmd.SetErr(&stderr)
+	defer validateCmd.SetOut(nil)
+	defer validateCmd.SetErr(nil)
+	defer func() { validateFormat = "text" }()
+	validateFormat = "json"
+
+	err := validateFiles(validateCmd, []string{valid, noContainers, unresolved})
+
+	assert.EqualError(t, err, "2 of 3 file(s) are not valid")
+	assert.Empty(t, stderr.String())
+	var results []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &results))
+	assert.Equal(t, []map[string]interface{}{
+		{
+			"path":   valid,
+			"valid":  true,
+			"errors": []interface{}{},
+		},
+		{
+			"path":  noContainers,
+			"valid": false,
+			"errors": []interface{}{
+				"building docker-compose configuration: workload does not have any containers to convert into a compose service",
+			},
+		},
+		{
+			"path":  unresolved,
+			"valid": false,
+			"errors": []interface{}{
+				"containers.hello.variables.HOST: can't resolve 'resources.nope.host': resource or property is not declared",
+				"containers.hello.variables.PORT: can't resolve 'resources.nope.port': resource or property is not declared",
+			},
+		},
+	}, results)
+}
@@ -10,98 +10,210 @@ package compose
 import (
 	"errors"
 	"fmt"
+	"net"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	compose "github.com/compose-spec/compose-go/types"
 	score "github.com/score-spec/score-go/types"
 )
 
+const (
+	// ProbeToolWget calls HTTP probes endpoints with 'wget' (default).
+	ProbeToolWget = "wget"
+	// ProbeToolCurl calls HTTP probes endpoints with 'curl'.
+	ProbeToolCurl = "curl"
+)
+
 // ConvertSpec converts SCORE specification into docker-compose configuration.
-func ConvertSpec(spec *score.WorkloadSpec) (*compose.Project, ExternalVariables, error) {
+// HTTP probes are converted into healthchecks which call the endpoint with the probe tool.
+func ConvertSpec(spec *score.WorkloadSpec, probeTool string) (*compose.Project, ExternalVariables, error) {
 	context, err := buildContext(spec.Metadata, spec.Resources)
 	if err != nil {
 		return nil, nil, fmt.Errorf("preparing context: %w", err)
 	}
 
-	for _, cSpec := range spec.Containers {
-		var externalVars = ExternalVariables(context.ListEnvVars())
-		var env = make(compose.MappingWithEquals, len(cSpec.Variables))
-		for key, val := range cSpec.Variables {
-			var envVarVal = context.Substitute(val)
-			env[key] = &envVarVal
-		}
+	_, cSpec, err := workloadContainer(spec)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		var dependsOn = make(compose.DependsOnConfig, len(spec.Resources))
-		for name, res := range spec.Resources {
-			if res.Type != "environment" && res.Type != "volume" {
-				dependsOn[name] = compose.ServiceDependency{Condition: "service_started"}
-			}
+	var externalVars = ExternalVariables(context.ListEnvVars())
+	var env = make(compose.MappingWithEquals, len(cSpec.Variables))
+	for key, val := range cSpec.Variables {
+		var envVarVal = context.Substitute(val)
+		env[key] = &envVarVal
+	}
+
+	var dependsOn = make(compose.DependsOnConfig, len(spec.Resources))
+	for name, res := range spec.Resources {
+		if res.Type != "environment" && res.Type != "volume" {
+			dependsOn[name] = compose.ServiceDependency{Condition: "service_started"}
 		}
+	}
 
-		var ports []compose.ServicePortConfig
-		if len(spec.Service.Ports) > 0 {
-			ports = []compose.ServicePortConfig{}
-			for _, pSpec := range spec.Service.Ports {
-				var pubPort = fmt.Sprintf("%v", pSpec.Port)
-				var tgtPort = pSpec.TargetPort
-				if pSpec.TargetPort == 0 {
-					tgtPort = pSpec.Port
-				}
-				ports = append(ports, compose.ServicePortConfig{
-					Published: pubPort,
-					Target:    uint32(tgtPort),
-					Protocol:  pSpec.Protocol,
-				})
+	var ports []compose.ServicePortConfig
+	if len(spec.Service.Ports) > 0 {
+		ports = []compose.ServicePortConfig{}
+		for _, pSpec := range spec.Service.Ports {
+			var pubPort = fmt.Sprintf("%v", pSpec.Port)
+			var tgtPort = pSpec.TargetPort
+			if pSpec.TargetPort == 0 {
+				tgtPort = pSpec.Port
 			}
+			ports = append(ports, compose.ServicePortConfig{
+				Published: pubPort,
+				Target:    uint32(tgtPort),
+				Protocol:  pSpec.Protocol,
+			})
 		}
-		// NOTE: Sorting is necessary for DeepEqual call within our Unit Tests to work reliably
-		sort.Slice(ports, func(i, j int) bool {
+	}
+	// NOTE: Sorting is necessary for DeepEqual call within our Unit Tests to work reliably
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Published != ports[j].Published {
 			return ports[i].Published < ports[j].Published
-		})
-		// END (NOTE)
-
-		var volumes []compose.ServiceVolumeConfig
-		if len(cSpec.Volumes) > 0 {
-			volumes = make([]compose.ServiceVolumeConfig, len(cSpec.Volumes))
-			for idx, vol := range cSpec.Volumes {
-				if vol.Path != "" {
-					return nil, nil, fmt.Errorf("can't mount named volume with sub path '%s': %w", vol.Path, errors.New("not supported"))
-				}
-				volumes[idx] = compose.ServiceVolumeConfig{
-					Type:     "volume",
-					Source:   context.Substitute(vol.Source),
-					Target:   vol.Target,
-					ReadOnly: vol.ReadOnly,
-				}
+		}
+		if ports[i].Target != ports[j].Target {
+			return ports[i].Target < ports[j].Target
+		}
+		return ports[i].Protocol < ports[j].Protocol
+	})
+	// END (NOTE)
+
+	var volumes []compose.ServiceVolumeConfig
+	if len(cSpec.Volumes) > 0 {
+		volumes = make([]compose.ServiceVolumeConfig, len(cSpec.Volumes))
+		var targets = make(map[string]bool, len(cSpec.Volumes))
+		for idx, vol := range cSpec.Volumes {
+			if vol.Path != "" {
+				return nil, nil, fmt.Errorf("can't mount named volume with sub path '%s': %w", vol.Path, errors.New("not supported"))
+			}
+			if targets[vol.Target] {
+				return nil, nil, fmt.Errorf("can't mount more than one volume at '%s': %w", vol.Target, errors.New("target path collision"))
+			}
+			targets[vol.Target] = true
+			volumes[idx] = compose.ServiceVolumeConfig{
+				Type:     "volume",
+				Source:   context.Substitute(vol.Source),
+				Target:   vol.Target,
+				ReadOnly: vol.ReadOnly,
 			}
 		}
-		// NOTE: Sorting is necessary for DeepEqual call within our Unit Tests to work reliably
-		sort.Slice(volumes, func(i, j int) bool {
+	}
+	// NOTE: Sorting is necessary for DeepEqual call within our Unit Tests to work reliably
+	sort.Slice(volumes, func(i, j int) bool {
+		if volumes[i].Source != volumes[j].Source {
 			return volumes[i].Source < volumes[j].Source
-		})
-		// END (NOTE)
-
-		var svc = compose.ServiceConfig{
-			Name:        spec.Metadata.Name,
-			Image:       cSpec.Image,
-			Entrypoint:  cSpec.Command,
-			Command:     cSpec.Args,
-			Environment: env,
-			DependsOn:   dependsOn,
-			Ports:       ports,
-			Volumes:     volumes,
 		}
+		return volumes[i].Target < volumes[j].Target
+	})
+	// END (NOTE)
 
-		var proj = compose.Project{
-			Services: compose.Services{
-				svc,
-			},
+	// NOTE: Compose supports only one healthcheck per service.
+	//       The readiness probe wins when both readiness and liveness probes are defined.
+	var healthCheck *compose.HealthCheckConfig
+	if healthCheck, err = convertProbe(cSpec.ReadinessProbe, probeTool); err != nil {
+		return nil, nil, fmt.Errorf("converting readiness probe: %w", err)
+	} else if healthCheck == nil {
+		if healthCheck, err = convertProbe(cSpec.LivenessProbe, probeTool); err != nil {
+			return nil, nil, fmt.Errorf("converting liveness probe: %w", err)
 		}
+	}
+
+	var svc = compose.ServiceConfig{
+		Name:        spec.Metadata.Name,
+		Image:       cSpec.Image,
+		Entrypoint:  cSpec.Command,
+		Command:     cSpec.Args,
+		Environment: env,
+		DependsOn:   dependsOn,
+		Ports:       ports,
+		Volumes:     volumes,
+		HealthCheck: healthCheck,
+	}
+
+	var proj = compose.Project{
+		Services: compose.Services{
+			svc,
+		},
+	}
 
-		// NOTE: Only one container per workload can be defined for compose.
-		//       All other containers will be ignored by this tool.
-		return &proj, externalVars, nil
+	return &proj, externalVars, nil
+}
+
+// workloadContainer picks the workload container which is converted into the compose service.
+// Only one container per workload can be defined for compose, so the first container by name is used.
+// All other containers are ignored by this tool.
+func workloadContainer(spec *score.WorkloadSpec) (string, score.ContainerSpec, error) {
+	var names = make([]string, 0, len(spec.Containers))
+	for name := range spec.Containers {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return "", score.ContainerSpec{}, errors.New("workload does not have any containers to convert into a compose service")
+	}
+	sort.Strings(names)
+
+	return names[0], spec.Containers[names[0]], nil
+}
+
+// convertProbe converts HTTP GET probe into compose healthcheck which calls the endpoint with 'wget' or 'curl'.
+// Returns nil if the probe is not defined.
+func convertProbe(probe score.ContainerProbeSpec, probeTool string) (*compose.HealthCheckConfig, error) {
+	var httpGet = probe.HTTPGet
+	if httpGet.Port == 0 && httpGet.Path == "" {
+		return nil, nil
+	}
+	if httpGet.Port == 0 {
+		return nil, errors.New("http probe port is not set")
+	}
+
+	var scheme = strings.ToLower(httpGet.Scheme)
+	if scheme == "" {
+		scheme = "http"
+	}
+	var host = httpGet.Host
+	if host == "" {
+		host = "localhost"
+	}
+	var path = httpGet.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	var test compose.HealthCheckTest
+	switch probeTool {
+	case ProbeToolCurl:
+		test = compose.HealthCheckTest{"CMD", "curl", "--fail", "--silent", "--output", "/dev/null"}
+	default:
+		test = compose.HealthCheckTest{"CMD", "wget", "--spider", "-q"}
+	}
+	for _, header := range httpGet.HTTPHeaders {
+		test = append(test, "--header", fmt.Sprintf("%s: %s", header.Name, header.Value))
+	}
+	test = append(test, fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(host, strconv.Itoa(httpGet.Port)), path))
+
+	var healthCheck = compose.HealthCheckConfig{
+		Test: test,
+	}
+	if probe.TimeoutSeconds > 0 {
+		var timeout = compose.Duration(time.Duration(probe.TimeoutSeconds) * time.Second)
+		healthCheck.Timeout = &timeout
+	}
+	if probe.PeriodSeconds > 0 {
+		var interval = compose.Duration(time.Duration(probe.PeriodSeconds) * time.Second)
+		healthCheck.Interval = &interval
+	}
+	if probe.InitialDelaySeconds > 0 {
+		var startPeriod = compose.Duration(time.Duration(probe.InitialDelaySeconds) * time.Second)
+		healthCheck.StartPeriod = &startPeriod
+	}
+	if probe.FailureThreshold > 0 {
+		var retries = uint64(probe.FailureThreshold)
+		healthCheck.Retries = &retries
 	}
 
-	return nil, nil, errors.New("workload does not have any containers to convert into a compose service")
+	return &healthCheck, nil
 }
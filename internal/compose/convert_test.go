@@ -10,6 +10,7 @@ package compose
 import (
 	"errors"
 	"testing"
+	"time"
 
 	compose "github.com/compose-spec/compose-go/types"
 	score "github.com/score-spec/score-go/types"
@@ -20,13 +21,21 @@ func TestScoreConvert(t *testing.T) {
 	var stringPtr = func(s string) *string {
 		return &s
 	}
+	var durationPtr = func(d time.Duration) *compose.Duration {
+		var res = compose.Duration(d)
+		return &res
+	}
+	var uint64Ptr = func(i uint64) *uint64 {
+		return &i
+	}
 
 	var tests = []struct {
-		Name    string
-		Source  *score.WorkloadSpec
-		Project *compose.Project
-		Vars    ExternalVariables
-		Error   error
+		Name      string
+		Source    *score.WorkloadSpec
+		ProbeTool string
+		Project   *compose.Project
+		Vars      ExternalVariables
+		Error     error
 	}{
 		// Success path
 		//
@@ -185,6 +194,315 @@ func TestScoreConvert(t *testing.T) {
 				"DNS_DOMAIN":       "",
 			},
 		},
+		{
+			Name: "Should sort volumes by source and target",
+			Source: &score.WorkloadSpec{
+				Metadata: score.WorkloadMeta{
+					Name: "test",
+				},
+				Containers: score.ContainersSpecs{
+					"backend": score.ContainerSpec{
+						Image: "busybox",
+						Volumes: []score.VolumeMountSpec{
+							{
+								Source: "${resources.data}",
+								Target: "/mnt/data",
+							},
+							{
+								Source: "${resources.data-backup}",
+								Target: "/mnt/backup",
+							},
+							{
+								Source:   "${resources.data}",
+								Target:   "/mnt/archive",
+								ReadOnly: true,
+							},
+						},
+					},
+				},
+				Resources: map[string]score.ResourceSpec{
+					"data": {
+						Type: "volume",
+					},
+					"data-backup": {
+						Type: "volume",
+					},
+				},
+			},
+			Project: &compose.Project{
+				Services: compose.Services{
+					{
+						Name:        "test",
+						Image:       "busybox",
+						Environment: compose.MappingWithEquals{},
+						Volumes: []compose.ServiceVolumeConfig{
+							{
+								Type:     "volume",
+								Source:   "data",
+								Target:   "/mnt/archive",
+								ReadOnly: true,
+							},
+							{
+								Type:   "volume",
+								Source: "data",
+								Target: "/mnt/data",
+							},
+							{
+								Type:   "volume",
+								Source: "data-backup",
+								Target: "/mnt/backup",
+							},
+						},
+						DependsOn: compose.DependsOnConfig{},
+					},
+				},
+			},
+			Vars: ExternalVariables{},
+		},
+		{
+			Name: "Should convert http readiness probe into healthcheck",
+			Source: &score.WorkloadSpec{
+				Metadata: score.WorkloadMeta{
+					Name: "test",
+				},
+				Containers: score.ContainersSpecs{
+					"backend": score.ContainerSpec{
+						Image: "busybox",
+						ReadinessProbe: score.ContainerProbeSpec{
+							HTTPGet: score.HTTPGetActionSpec{
+								Path: "/health/ready",
+								Port: 8080,
+							},
+						},
+					},
+				},
+			},
+			Project: &compose.Project{
+				Services: compose.Services{
+					{
+						Name:        "test",
+						Image:       "busybox",
+						Environment: compose.MappingWithEquals{},
+						DependsOn:   compose.DependsOnConfig{},
+						HealthCheck: &compose.HealthCheckConfig{
+							Test: compose.HealthCheckTest{"CMD", "wget", "--spider", "-q", "http://localhost:8080/health/ready"},
+						},
+					},
+				},
+			},
+			Vars: ExternalVariables{},
+		},
+		{
+			Name: "Should convert https readiness probe into healthcheck",
+			Source: &score.WorkloadSpec{
+				Metadata: score.WorkloadMeta{
+					Name: "test",
+				},
+				Containers: score.ContainersSpecs{
+					"backend": score.ContainerSpec{
+						Image: "busybox",
+						ReadinessProbe: score.ContainerProbeSpec{
+							HTTPGet: score.HTTPGetActionSpec{
+								Scheme: "HTTPS",
+								Host:   "127.0.0.1",
+								Port:   8443,
+								HTTPHeaders: []score.HTTPHeaderSpec{
+									{Name: "Custom-Header", Value: "Awesome"},
+								},
+							},
+							InitialDelaySeconds: 5,
+							TimeoutSeconds:      2,
+							PeriodSeconds:       10,
+							FailureThreshold:    3,
+						},
+					},
+				},
+			},
+			Project: &compose.Project{
+				Services: compose.Services{
+					{
+						Name:        "test",
+						Image:       "busybox",
+						Environment: compose.MappingWithEquals{},
+						DependsOn:   compose.DependsOnConfig{},
+						HealthCheck: &compose.HealthCheckConfig{
+							Test:        compose.HealthCheckTest{"CMD", "wget", "--spider", "-q", "--header", "Custom-Header: Awesome", "https://127.0.0.1:8443/"},
+							Timeout:     durationPtr(2 * time.Second),
+							Interval:    durationPtr(10 * time.Second),
+							StartPeriod: durationPtr(5 * time.Second),
+							Retries:     uint64Ptr(3),
+						},
+					},
+				},
+			},
+			Vars: ExternalVariables{},
+		},
+		{
+			Name: "Should convert http probe into healthcheck with curl",
+			Source: &score.WorkloadSpec{
+				Metadata: score.WorkloadMeta{
+					Name: "test",
+				},
+				Containers: score.ContainersSpecs{
+					"backend": score.ContainerSpec{
+						Image: "busybox",
+						ReadinessProbe: score.ContainerProbeSpec{
+							HTTPGet: score.HTTPGetActionSpec{
+								Scheme: "HTTPS",
+								Path:   "health/ready",
+								Port:   8443,
+								HTTPHeaders: []score.HTTPHeaderSpec{
+									{Name: "Custom-Header", Value: "Awesome"},
+								},
+							},
+						},
+					},
+				},
+			},
+			ProbeTool: ProbeToolCurl,
+			Project: &compose.Project{
+				Services: compose.Services{
+					{
+						Name:        "test",
+						Image:       "busybox",
+						Environment: compose.MappingWithEquals{},
+						DependsOn:   compose.DependsOnConfig{},
+						HealthCheck: &compose.HealthCheckConfig{
+							Test: compose.HealthCheckTest{"CMD", "curl", "--fail", "--silent", "--output", "/dev/null", "--header", "Custom-Header: Awesome", "https://localhost:8443/health/ready"},
+						},
+					},
+				},
+			},
+			Vars: ExternalVariables{},
+		},
+		{
+			Name: "Should convert liveness probe into healthcheck",
+			Source: &score.WorkloadSpec{
+				Metadata: score.WorkloadMeta{
+					Name: "test",
+				},
+				Containers: score.ContainersSpecs{
+					"backend": score.ContainerSpec{
+						Image: "busybox",
+						LivenessProbe: score.ContainerProbeSpec{
+							HTTPGet: score.HTTPGetActionSpec{
+								Path: "/health/alive",
+								Port: 8080,
+							},
+						},
+					},
+				},
+			},
+			Project: &compose.Project{
+				Services: compose.Services{
+					{
+						Name:        "test",
+						Image:       "busybox",
+						Environment: compose.MappingWithEquals{},
+						DependsOn:   compose.DependsOnConfig{},
+						HealthCheck: &compose.HealthCheckConfig{
+							Test: compose.HealthCheckTest{"CMD", "wget", "--spider", "-q", "http://localhost:8080/health/alive"},
+						},
+					},
+				},
+			},
+			Vars: ExternalVariables{},
+		},
+		{
+			Name: "Should prefer readiness probe over liveness probe",
+			Source: &score.WorkloadSpec{
+				Metadata: score.WorkloadMeta{
+					Name: "test",
+				},
+				Containers: score.ContainersSpecs{
+					"backend": score.ContainerSpec{
+						Image: "busybox",
+						LivenessProbe: score.ContainerProbeSpec{
+							HTTPGet: score.HTTPGetActionSpec{
+								Path: "/health/alive",
+								Port: 8080,
+							},
+						},
+						ReadinessProbe: score.ContainerProbeSpec{
+							HTTPGet: score.HTTPGetActionSpec{
+								Path: "/health/ready",
+								Port: 8080,
+							},
+						},
+					},
+				},
+			},
+			Project: &compose.Project{
+				Services: compose.Services{
+					{
+						Name:        "test",
+						Image:       "busybox",
+						Environment: compose.MappingWithEquals{},
+						DependsOn:   compose.DependsOnConfig{},
+						HealthCheck: &compose.HealthCheckConfig{
+							Test: compose.HealthCheckTest{"CMD", "wget", "--spider", "-q", "http://localhost:8080/health/ready"},
+						},
+					},
+				},
+			},
+			Vars: ExternalVariables{},
+		},
+		{
+			Name: "Should sort ports by published port, target port and protocol",
+			Source: &score.WorkloadSpec{
+				Metadata: score.WorkloadMeta{
+					Name: "test",
+				},
+				Service: score.ServiceSpec{
+					Ports: score.ServicePortsSpecs{
+						"dns-alt": score.ServicePortSpec{
+							Port:       53,
+							TargetPort: 5353,
+						},
+						"dns-udp": score.ServicePortSpec{
+							Port:     53,
+							Protocol: "UDP",
+						},
+						"dns-tcp": score.ServicePortSpec{
+							Port:     53,
+							Protocol: "TCP",
+						},
+					},
+				},
+				Containers: score.ContainersSpecs{
+					"backend": score.ContainerSpec{
+						Image: "busybox",
+					},
+				},
+			},
+			Project: &compose.Project{
+				Services: compose.Services{
+					{
+						Name:        "test",
+						Image:       "busybox",
+						Environment: compose.MappingWithEquals{},
+						DependsOn:   compose.DependsOnConfig{},
+						Ports: []compose.ServicePortConfig{
+							{
+								Published: "53",
+								Target:    53,
+								Protocol:  "TCP",
+							},
+							{
+								Published: "53",
+								Target:    53,
+								Protocol:  "UDP",
+							},
+							{
+								Published: "53",
+								Target:    5353,
+							},
+						},
+					},
+				},
+			},
+			Vars: ExternalVariables{},
+		},
 
 		// Errors handling
 		//
@@ -215,11 +533,62 @@ func TestScoreConvert(t *testing.T) {
 			},
 			Error: errors.New("not supported"),
 		},
+		{
+			Name: "Should report an error for http probe without port",
+			Source: &score.WorkloadSpec{
+				Metadata: score.WorkloadMeta{
+					Name: "test",
+				},
+				Containers: score.ContainersSpecs{
+					"backend": score.ContainerSpec{
+						Image: "busybox",
+						LivenessProbe: score.ContainerProbeSpec{
+							HTTPGet: score.HTTPGetActionSpec{
+								Path: "/health",
+							},
+						},
+					},
+				},
+			},
+			Error: errors.New("http probe port is not set"),
+		},
+		{
+			Name: "Should report an error for volumes with the same target path",
+			Source: &score.WorkloadSpec{
+				Metadata: score.WorkloadMeta{
+					Name: "test",
+				},
+				Containers: score.ContainersSpecs{
+					"backend": score.ContainerSpec{
+						Image: "busybox",
+						Volumes: []score.VolumeMountSpec{
+							{
+								Source: "${resources.data}",
+								Target: "/mnt/data",
+							},
+							{
+								Source: "${resources.data-backup}",
+								Target: "/mnt/data",
+							},
+						},
+					},
+				},
+				Resources: map[string]score.ResourceSpec{
+					"data": {
+						Type: "volume",
+					},
+					"data-backup": {
+						Type: "volume",
+					},
+				},
+			},
+			Error: errors.New("can't mount more than one volume at '/mnt/data'"),
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.Name, func(t *testing.T) {
-			proj, vars, err := ConvertSpec(tt.Source)
+			proj, vars, err := ConvertSpec(tt.Source, tt.ProbeTool)
 
 			if tt.Error != nil {
 				// On Error
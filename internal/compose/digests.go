@@ -0,0 +1,40 @@
+/*
+Apache Score
+Copyright 2022 The Apache Software Foundation
+
+This product includes software developed at
+The Apache Software Foundation (http://www.apache.org/).
+*/
+package compose
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/distribution/distribution/v3/reference"
+	godigest "github.com/opencontainers/go-digest"
+
+	compose "github.com/compose-spec/compose-go/types"
+)
+
+// PinImageDigests replaces services images tags with the digests currently published in the registry.
+// Images which are already pinned to a digest are left as is.
+func PinImageDigests(proj *compose.Project) error {
+	return proj.ResolveImages(resolveImageDigest)
+}
+
+// resolveImageDigest queries the registry for the image digest via docker CLI
+func resolveImageDigest(named reference.Named) (godigest.Digest, error) {
+	var ref = reference.FamiliarString(named)
+	out, err := exec.Command("docker", "buildx", "imagetools", "inspect", "--format", "{{.Manifest.Digest}}", ref).Output()
+	if err != nil {
+		return "", fmt.Errorf("resolving digest for '%s': %w", ref, err)
+	}
+
+	digest, err := godigest.Parse(strings.TrimSpace(string(out)))
+	if err != nil {
+		return "", fmt.Errorf("resolving digest for '%s': %w", ref, err)
+	}
+	return digest, nil
+}
@@ -0,0 +1,52 @@
+/*
+Apache Score
+Copyright 2022 The Apache Software Foundation
+
+This product includes software developed at
+The Apache Software Foundation (http://www.apache.org/).
+*/
+package compose
+
+import (
+	"os/exec"
+	"regexp"
+	"testing"
+
+	compose "github.com/compose-spec/compose-go/types"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func TestPinImageDigests(t *testing.T) {
+	if err := exec.Command("docker", "buildx", "version").Run(); err != nil {
+		t.Skip("docker buildx is not available")
+	}
+
+	var proj = &compose.Project{
+		Services: compose.Services{
+			{
+				Name:  "test",
+				Image: "busybox",
+			},
+			{
+				Name:  "pinned",
+				Image: "busybox@sha256:1111111111111111111111111111111111111111111111111111111111111111",
+			},
+			{
+				Name: "build",
+				Build: &compose.BuildConfig{
+					Context: ".",
+				},
+			},
+		},
+	}
+
+	// NOTE: Resolving digests requires access to the registry, which is not always available
+	if err := PinImageDigests(proj); err != nil {
+		t.Skipf("registry is not available: %v", err)
+	}
+	// END (NOTE)
+
+	assert.Regexp(t, regexp.MustCompile(`^docker.io/library/busybox:latest@sha256:[0-9a-f]{64}$`), proj.Services[0].Image)
+	assert.Equal(t, "docker.io/library/busybox@sha256:1111111111111111111111111111111111111111111111111111111111111111", proj.Services[1].Image)
+	assert.Equal(t, "", proj.Services[2].Image)
+}
@@ -0,0 +1,81 @@
+/*
+Apache Score
+Copyright 2022 The Apache Software Foundation
+
+This product includes software developed at
+The Apache Software Foundation (http://www.apache.org/).
+*/
+package compose
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	score "github.com/score-spec/score-go/types"
+)
+
+// EnvVarExplanation describes where a container environment variable value comes from.
+type EnvVarExplanation struct {
+	Container string
+	Name      string
+	Value     string
+	Origins   []string
+}
+
+// ExplainEnv reports the origins of the environment variables of the container converted into the compose service.
+func ExplainEnv(spec *score.WorkloadSpec) ([]EnvVarExplanation, error) {
+	context, err := buildContext(spec.Metadata, spec.Resources)
+	if err != nil {
+		return nil, fmt.Errorf("preparing context: %w", err)
+	}
+
+	containerName, cSpec, err := workloadContainer(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var explanations = make([]EnvVarExplanation, 0, len(cSpec.Variables))
+	for key, val := range cSpec.Variables {
+		var origins = make([]string, 0)
+		for _, ref := range listReferences(val) {
+			origins = append(origins, fmt.Sprintf("%s '%s'", referenceOrigin(ref, context, spec.Resources), ref))
+		}
+		if len(origins) == 0 {
+			origins = append(origins, "literal")
+		}
+
+		explanations = append(explanations, EnvVarExplanation{
+			Container: containerName,
+			Name:      key,
+			Value:     context.Substitute(val),
+			Origins:   origins,
+		})
+	}
+	// NOTE: Sorting is necessary for the output to be stable
+	sort.Slice(explanations, func(i, j int) bool {
+		return explanations[i].Name < explanations[j].Name
+	})
+	// END (NOTE)
+
+	return explanations, nil
+}
+
+// referenceOrigin describes the kind of object the '${...}' template reference points to
+func referenceOrigin(ref string, context templatesContext, resources score.ResourcesSpecs) string {
+	if _, ok := context[ref]; !ok {
+		return "unresolved reference"
+	}
+
+	var parts = strings.SplitN(ref, ".", 3)
+	switch {
+	case parts[0] == "metadata":
+		return "workload metadata"
+	case len(parts) == 2:
+		return "resource name"
+	case resources[parts[1]].Type == "environment":
+		return "environment variable"
+	default:
+		return "resource property"
+	}
+}
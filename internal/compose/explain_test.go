@@ -0,0 +1,143 @@
+/*
+Apache Score
+Copyright 2022 The Apache Software Foundation
+
+This product includes software developed at
+The Apache Software Foundation (http://www.apache.org/).
+*/
+package compose
+
+import (
+	"testing"
+
+	compose "github.com/compose-spec/compose-go/types"
+	score "github.com/score-spec/score-go/types"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func TestExplainEnv(t *testing.T) {
+	var spec = &score.WorkloadSpec{
+		Metadata: score.WorkloadMeta{
+			Name: "test",
+		},
+		Containers: score.ContainersSpecs{
+			"backend": score.ContainerSpec{
+				Image: "busybox",
+				Variables: map[string]string{
+					"CONNECTION_STRING": "postgresql://${resources.app-db.host}:${resources.app-db.port}/${resources.app-db.name}",
+					"DEBUG":             "${resources.env.DEBUG}",
+					"DOMAIN_NAME":       "${resources.dns.domain_name}",
+					"LOGS_LEVEL":        "$${LOGS_LEVEL}",
+					"SERVICE_NAME":      "${metadata.name}",
+					"VERSION":           "1.0.0",
+				},
+			},
+		},
+		Resources: map[string]score.ResourceSpec{
+			"env": {
+				Type: "environment",
+				Properties: map[string]score.ResourcePropertySpec{
+					"DEBUG": {Default: false},
+				},
+			},
+			"app-db": {
+				Type: "postgres",
+				Properties: map[string]score.ResourcePropertySpec{
+					"host": {Default: "localhost"},
+					"port": {Default: 5432},
+					"name": {Required: true},
+				},
+			},
+			"dns": {
+				Type: "dns",
+			},
+		},
+	}
+
+	explanations, err := ExplainEnv(spec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []EnvVarExplanation{
+		{
+			Container: "backend",
+			Name:      "CONNECTION_STRING",
+			Value:     "postgresql://${APP_DB_HOST-localhost}:${APP_DB_PORT-5432}/${APP_DB_NAME?err}",
+			Origins: []string{
+				"resource property 'resources.app-db.host'",
+				"resource property 'resources.app-db.port'",
+				"resource property 'resources.app-db.name'",
+			},
+		},
+		{
+			Container: "backend",
+			Name:      "DEBUG",
+			Value:     "${DEBUG-false}",
+			Origins:   []string{"environment variable 'resources.env.DEBUG'"},
+		},
+		{
+			Container: "backend",
+			Name:      "DOMAIN_NAME",
+			Value:     "",
+			Origins:   []string{"unresolved reference 'resources.dns.domain_name'"},
+		},
+		{
+			Container: "backend",
+			Name:      "LOGS_LEVEL",
+			Value:     "${LOGS_LEVEL}",
+			Origins:   []string{"literal"},
+		},
+		{
+			Container: "backend",
+			Name:      "SERVICE_NAME",
+			Value:     "test",
+			Origins:   []string{"workload metadata 'metadata.name'"},
+		},
+		{
+			Container: "backend",
+			Name:      "VERSION",
+			Value:     "1.0.0",
+			Origins:   []string{"literal"},
+		},
+	}, explanations)
+}
+
+func TestExplainEnvMultipleContainers(t *testing.T) {
+	var spec = &score.WorkloadSpec{
+		Metadata: score.WorkloadMeta{
+			Name: "test",
+		},
+		Containers: score.ContainersSpecs{
+			"b": score.ContainerSpec{
+				Image: "busybox",
+				Variables: map[string]string{
+					"B": "2",
+				},
+			},
+			"a": score.ContainerSpec{
+				Image: "busybox",
+				Variables: map[string]string{
+					"A": "1",
+				},
+			},
+		},
+	}
+
+	explanations, err := ExplainEnv(spec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []EnvVarExplanation{
+		{
+			Container: "a",
+			Name:      "A",
+			Value:     "1",
+			Origins:   []string{"literal"},
+		},
+	}, explanations)
+
+	// NOTE: Explanations must match the environment of the generated compose service
+	proj, _, err := ConvertSpec(spec, ProbeToolWget)
+	assert.NoError(t, err)
+	var value = "1"
+	assert.Equal(t, compose.MappingWithEquals{"A": &value}, proj.Services[0].Environment)
+	// END (NOTE)
+}
@@ -0,0 +1,37 @@
+/*
+Apache Score
+Copyright 2022 The Apache Software Foundation
+
+This product includes software developed at
+The Apache Software Foundation (http://www.apache.org/).
+*/
+package compose
+
+import (
+	"bytes"
+
+	"github.com/compose-spec/compose-go/loader"
+	compose "github.com/compose-spec/compose-go/types"
+)
+
+// MergeOverlay merges docker-compose configuration fragment over the project.
+// The same merge rules are applied as for multiple compose files passed to docker compose.
+func MergeOverlay(proj *compose.Project, filename string, overlay []byte) (*compose.Project, error) {
+	var buf bytes.Buffer
+	if err := WriteYAML(&buf, proj); err != nil {
+		return nil, err
+	}
+
+	return loader.Load(compose.ConfigDetails{
+		ConfigFiles: []compose.ConfigFile{
+			{Filename: "score-compose", Content: buf.Bytes()},
+			{Filename: filename, Content: overlay},
+		},
+		Environment: map[string]string{},
+	}, func(opts *loader.Options) {
+		// NOTE: Environment variables references must be preserved in the output as is.
+		opts.SkipInterpolation = true
+		opts.SkipNormalization = true
+		opts.SkipConsistencyCheck = true
+	})
+}
@@ -0,0 +1,104 @@
+/*
+Apache Score
+Copyright 2022 The Apache Software Foundation
+
+This product includes software developed at
+The Apache Software Foundation (http://www.apache.org/).
+*/
+package compose
+
+import (
+	"bytes"
+	"testing"
+
+	compose "github.com/compose-spec/compose-go/types"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func TestMergeOverlay(t *testing.T) {
+	var stringPtr = func(s string) *string {
+		return &s
+	}
+
+	var tests = []struct {
+		Name    string
+		Source  *compose.Project
+		Overlay []byte
+		Output  []byte
+		Error   string
+	}{
+		// Success path
+		//
+		{
+			Name: "Should merge overlay over the docker-compose spec",
+			Source: &compose.Project{
+				Services: compose.Services{
+					{
+						Name:  "test",
+						Image: "busybox",
+						Environment: compose.MappingWithEquals{
+							"DEBUG": stringPtr("${DEBUG-false}"),
+						},
+					},
+				},
+			},
+			Overlay: []byte(`services:
+  test:
+    image: busybox:1.36
+    restart: always
+    environment:
+      LOGS_LEVEL: WARN
+  proxy:
+    image: nginx
+`),
+			Output: []byte(`services:
+  proxy:
+    image: nginx
+  test:
+    environment:
+      DEBUG: ${DEBUG-false}
+      LOGS_LEVEL: WARN
+    image: busybox:1.36
+    restart: always
+`),
+		},
+
+		// Errors handling
+		//
+		{
+			Name: "Should report an error for invalid overlay",
+			Source: &compose.Project{
+				Services: compose.Services{
+					{
+						Name:  "test",
+						Image: "busybox",
+					},
+				},
+			},
+			Overlay: []byte(`services:
+  test:
+    restart: [always]
+`),
+			Error: "restart",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			proj, err := MergeOverlay(tt.Source, "overlay.yaml", tt.Overlay)
+
+			if tt.Error != "" {
+				// On Error
+				//
+				assert.ErrorContains(t, err, tt.Error)
+			} else {
+				// On Success
+				//
+				assert.NoError(t, err)
+				var buf bytes.Buffer
+				assert.NoError(t, WriteYAML(&buf, proj))
+				assert.Equal(t, string(tt.Output), buf.String())
+			}
+		})
+	}
+}
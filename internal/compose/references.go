@@ -0,0 +1,60 @@
+/*
+Apache Score
+Copyright 2022 The Apache Software Foundation
+
+This product includes software developed at
+The Apache Software Foundation (http://www.apache.org/).
+*/
+package compose
+
+import (
+	"fmt"
+	"sort"
+
+	score "github.com/score-spec/score-go/types"
+)
+
+// CheckReferences reports '${...}' references within containers variables and volumes sources
+// which can't be resolved, because the resource or property is not declared.
+func CheckReferences(spec *score.WorkloadSpec) []error {
+	context, err := buildContext(spec.Metadata, spec.Resources)
+	if err != nil {
+		return []error{fmt.Errorf("preparing context: %w", err)}
+	}
+
+	var errs = make([]error, 0)
+	var check = func(field, src string) {
+		for _, ref := range listReferences(src) {
+			if _, ok := context[ref]; !ok {
+				errs = append(errs, fmt.Errorf("%s: can't resolve '%s': resource or property is not declared", field, ref))
+			}
+		}
+	}
+
+	// NOTE: Sorting is necessary for the output to be stable
+	var containerNames = make([]string, 0, len(spec.Containers))
+	for name := range spec.Containers {
+		containerNames = append(containerNames, name)
+	}
+	sort.Strings(containerNames)
+	// END (NOTE)
+
+	for _, containerName := range containerNames {
+		var cSpec = spec.Containers[containerName]
+
+		var keys = make([]string, 0, len(cSpec.Variables))
+		for key := range cSpec.Variables {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			check(fmt.Sprintf("containers.%s.variables.%s", containerName, key), cSpec.Variables[key])
+		}
+
+		for idx, vol := range cSpec.Volumes {
+			check(fmt.Sprintf("containers.%s.volumes[%d].source", containerName, idx), vol.Source)
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,65 @@
+/*
+Apache Score
+Copyright 2022 The Apache Software Foundation
+
+This product includes software developed at
+The Apache Software Foundation (http://www.apache.org/).
+*/
+package compose
+
+import (
+	"errors"
+	"testing"
+
+	score "github.com/score-spec/score-go/types"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func TestCheckReferences(t *testing.T) {
+	var spec = &score.WorkloadSpec{
+		Metadata: score.WorkloadMeta{
+			Name: "test",
+		},
+		Containers: score.ContainersSpecs{
+			"backend": score.ContainerSpec{
+				Image: "busybox",
+				Variables: map[string]string{
+					"DB_HOST":      "${resources.app-db.host}",
+					"DB_PORT":      "${resources.app-db.port}",
+					"LOGS_LEVEL":   "$${LOGS_LEVEL}",
+					"SERVICE_NAME": "${metadata.name}",
+					"UNKNOWN_HOST": "${resources.nope.host}",
+				},
+				Volumes: []score.VolumeMountSpec{
+					{
+						Source: "${resources.data}",
+						Target: "/mnt/data",
+					},
+					{
+						Source: "${resources.backup}",
+						Target: "/mnt/backup",
+					},
+				},
+			},
+		},
+		Resources: map[string]score.ResourceSpec{
+			"app-db": {
+				Type: "postgres",
+				Properties: map[string]score.ResourcePropertySpec{
+					"host": {Default: "localhost"},
+				},
+			},
+			"data": {
+				Type: "volume",
+			},
+		},
+	}
+
+	errs := CheckReferences(spec)
+
+	assert.Equal(t, []error{
+		errors.New("containers.backend.variables.DB_PORT: can't resolve 'resources.app-db.port': resource or property is not declared"),
+		errors.New("containers.backend.variables.UNKNOWN_HOST: can't resolve 'resources.nope.host': resource or property is not declared"),
+		errors.New("containers.backend.volumes[1].source: can't resolve 'resources.backup': resource or property is not declared"),
+	}, errs)
+}
@@ -0,0 +1,47 @@
+/*
+Apache Score
+Copyright 2022 The Apache Software Foundation
+
+This product includes software developed at
+The Apache Software Foundation (http://www.apache.org/).
+*/
+package compose
+
+import (
+	compose "github.com/compose-spec/compose-go/types"
+)
+
+// SplitOverride moves local environment specific settings (published ports, build instructions and bind mounts)
+// out of the project into a separate docker-compose override project.
+func SplitOverride(proj *compose.Project) *compose.Project {
+	var override = compose.Project{
+		Services: compose.Services{},
+	}
+
+	for idx := range proj.Services {
+		var svc = &proj.Services[idx]
+		var ovr = compose.ServiceConfig{
+			Name:  svc.Name,
+			Ports: svc.Ports,
+			Build: svc.Build,
+		}
+		svc.Ports = nil
+		svc.Build = nil
+
+		var volumes []compose.ServiceVolumeConfig
+		for _, vol := range svc.Volumes {
+			if vol.Type == compose.VolumeTypeBind {
+				ovr.Volumes = append(ovr.Volumes, vol)
+			} else {
+				volumes = append(volumes, vol)
+			}
+		}
+		svc.Volumes = volumes
+
+		if ovr.Ports != nil || ovr.Build != nil || ovr.Volumes != nil {
+			override.Services = append(override.Services, ovr)
+		}
+	}
+
+	return &override
+}
@@ -0,0 +1,96 @@
+/*
+Apache Score
+Copyright 2022 The Apache Software Foundation
+
+This product includes software developed at
+The Apache Software Foundation (http://www.apache.org/).
+*/
+package compose
+
+import (
+	"testing"
+
+	compose "github.com/compose-spec/compose-go/types"
+	assert "github.com/stretchr/testify/assert"
+)
+
+func TestSplitOverride(t *testing.T) {
+	var proj = &compose.Project{
+		Services: compose.Services{
+			{
+				Name:  "test",
+				Image: "busybox",
+				Build: &compose.BuildConfig{
+					Context: ".",
+				},
+				Ports: []compose.ServicePortConfig{
+					{
+						Published: "80",
+						Target:    8080,
+					},
+				},
+				Volumes: []compose.ServiceVolumeConfig{
+					{
+						Type:   "bind",
+						Source: "./data",
+						Target: "/mnt/data",
+					},
+					{
+						Type:   "volume",
+						Source: "cache",
+						Target: "/mnt/cache",
+					},
+				},
+			},
+			{
+				Name:  "db",
+				Image: "postgres",
+			},
+		},
+	}
+
+	override := SplitOverride(proj)
+
+	assert.Equal(t, &compose.Project{
+		Services: compose.Services{
+			{
+				Name:  "test",
+				Image: "busybox",
+				Volumes: []compose.ServiceVolumeConfig{
+					{
+						Type:   "volume",
+						Source: "cache",
+						Target: "/mnt/cache",
+					},
+				},
+			},
+			{
+				Name:  "db",
+				Image: "postgres",
+			},
+		},
+	}, proj)
+	assert.Equal(t, &compose.Project{
+		Services: compose.Services{
+			{
+				Name: "test",
+				Build: &compose.BuildConfig{
+					Context: ".",
+				},
+				Ports: []compose.ServicePortConfig{
+					{
+						Published: "80",
+						Target:    8080,
+					},
+				},
+				Volumes: []compose.ServiceVolumeConfig{
+					{
+						Type:   "bind",
+						Source: "./data",
+						Target: "/mnt/data",
+					},
+				},
+			},
+		},
+	}, override)
+}
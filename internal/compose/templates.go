@@ -78,6 +78,19 @@ func (context templatesContext) Substitute(src string) string {
 	return os.Expand(src, context.mapVar)
 }
 
+// listReferences lists all '${...}' templates references in a source string
+func listReferences(src string) []string {
+	var refs = make([]string, 0)
+	os.Expand(src, func(ref string) string {
+		// NOTE: Escaped sequences ("$${abc}") are not references. See mapVar(..) for details.
+		if ref != "" && ref != "$" {
+			refs = append(refs, ref)
+		}
+		return ""
+	})
+	return refs
+}
+
 // MapVar replaces objects and properties references with corresponding values
 // Returns an empty string if the reference can't be resolved
 func (context templatesContext) mapVar(ref string) string {
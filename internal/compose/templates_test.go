@@ -113,6 +113,14 @@ func TestSubstitute(t *testing.T) {
 		context.Substitute("postgresql://${resources.db.user}:${resources.db.password}@${resources.db.host}:${resources.db.port}/${resources.db.name}"))
 }
 
+func TestListReferences(t *testing.T) {
+	assert.Equal(t, []string{}, listReferences(""))
+	assert.Equal(t, []string{}, listReferences("abc"))
+	assert.Equal(t, []string{}, listReferences("$${abc}"))
+	assert.Equal(t, []string{"metadata.name"}, listReferences("${metadata.name}"))
+	assert.Equal(t, []string{"resources.db.host", "resources.db.port"}, listReferences("${resources.db.host}:${resources.db.port} $${abc}"))
+}
+
 func TestEnvVarPattern(t *testing.T) {
 	assert.Equal(t, []string(nil), envVarPattern.FindStringSubmatch(""))
 	assert.Equal(t, []string(nil), envVarPattern.FindStringSubmatch("ENV_VAR"))
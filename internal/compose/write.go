@@ -8,7 +8,10 @@ The Apache Software Foundation (http://www.apache.org/).
 package compose
 
 import (
+	"encoding/json"
+	"fmt"
 	"io"
+	"sort"
 
 	compose "github.com/compose-spec/compose-go/types"
 	yaml "gopkg.in/yaml.v3"
@@ -20,3 +23,46 @@ func WriteYAML(w io.Writer, proj *compose.Project) error {
 	enc.SetIndent(2)
 	return enc.Encode(proj)
 }
+
+// WriteJSON exports docker-compose specification in JSON.
+func WriteJSON(w io.Writer, proj *compose.Project) error {
+	// NOTE: Some compose types lack 'omitempty' in their JSON tags (e.g. 'command' and 'entrypoint'),
+	//       so the project is encoded via YAML, which omits empty fields as the compose schema expects.
+	src, err := yaml.Marshal(proj)
+	if err != nil {
+		return err
+	}
+	var content map[string]interface{}
+	if err := yaml.Unmarshal(src, &content); err != nil {
+		return err
+	}
+	// END (NOTE)
+
+	var enc = json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(content)
+}
+
+// WriteEnv exports external variables with their default values in .env file format.
+// Variables without default values are written as empty 'KEY=' entries, unless skipEmpty is set.
+func WriteEnv(w io.Writer, vars ExternalVariables, skipEmpty bool) error {
+	envVars := make([]string, 0, len(vars))
+	for key, val := range vars {
+		if val == nil {
+			val = ""
+		}
+		if skipEmpty && fmt.Sprintf("%v", val) == "" {
+			continue
+		}
+		var envVar = fmt.Sprintf("%s=%v\n", key, val)
+		envVars = append(envVars, envVar)
+	}
+	sort.Strings(envVars)
+
+	for _, envVar := range envVars {
+		if _, err := io.WriteString(w, envVar); err != nil {
+			return err
+		}
+	}
+	return nil
+}
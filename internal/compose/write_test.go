@@ -12,8 +12,11 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/compose-spec/compose-go/loader"
 	compose "github.com/compose-spec/compose-go/types"
+	score "github.com/score-spec/score-go/types"
 	assert "github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v3"
 )
 
 func TestYamlEncode(t *testing.T) {
@@ -70,3 +73,160 @@ func TestYamlEncode(t *testing.T) {
 		})
 	}
 }
+
+func TestJsonEncode(t *testing.T) {
+	var tests = []struct {
+		Name   string
+		Source *compose.Project
+		Output []byte
+		Error  error
+	}{
+		{
+			Name: "Should encode the docker-compose spec",
+			Source: &compose.Project{
+				Services: compose.Services{
+					{
+						Name:  "test",
+						Image: "busybox",
+						Entrypoint: compose.ShellCommand{
+							"/bin/sh",
+						},
+						Command: compose.ShellCommand{
+							"-c",
+							"while true; echo ...sleeping 10 sec...; sleep 10; done",
+						},
+					},
+				},
+			},
+			Output: []byte(`{
+  "services": {
+    "test": {
+      "command": [
+        "-c",
+        "while true; echo ...sleeping 10 sec...; sleep 10; done"
+      ],
+      "entrypoint": [
+        "/bin/sh"
+      ],
+      "image": "busybox"
+    }
+  }
+}
+`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			w := bufio.NewWriter(&buf)
+
+			err := WriteJSON(w, tt.Source)
+			w.Flush()
+
+			if tt.Error != nil {
+				// On Error
+				//
+				assert.ErrorContains(t, err, tt.Error.Error())
+			} else {
+				// On Success
+				//
+				assert.NoError(t, err)
+				assert.Equal(t, string(tt.Output), buf.String())
+
+				// JSON output should describe the same project as YAML output
+				var yamlBuf = bytes.Buffer{}
+				assert.NoError(t, WriteYAML(&yamlBuf, tt.Source))
+				var fromJSON, fromYAML map[string]interface{}
+				assert.NoError(t, yaml.Unmarshal(buf.Bytes(), &fromJSON))
+				assert.NoError(t, yaml.Unmarshal(yamlBuf.Bytes(), &fromYAML))
+				assert.Equal(t, fromYAML, fromJSON)
+			}
+		})
+	}
+}
+
+func TestJsonEncodeLoadable(t *testing.T) {
+	proj, _, err := ConvertSpec(&score.WorkloadSpec{
+		Metadata: score.WorkloadMeta{
+			Name: "test",
+		},
+		Containers: score.ContainersSpecs{
+			"backend": score.ContainerSpec{
+				Image: "busybox",
+			},
+		},
+	}, ProbeToolWget)
+	assert.NoError(t, err)
+
+	var buf = bytes.Buffer{}
+	assert.NoError(t, WriteJSON(&buf, proj))
+
+	assert.Equal(t, `{
+  "services": {
+    "test": {
+      "image": "busybox"
+    }
+  }
+}
+`, buf.String())
+
+	// JSON output should pass the compose schema validation
+	_, err = loader.Load(compose.ConfigDetails{
+		ConfigFiles: []compose.ConfigFile{
+			{Filename: "compose.json", Content: buf.Bytes()},
+		},
+		Environment: map[string]string{},
+	}, func(opts *loader.Options) {
+		opts.SkipConsistencyCheck = true
+	})
+	assert.NoError(t, err)
+}
+
+func TestEnvEncode(t *testing.T) {
+	var tests = []struct {
+		Name      string
+		Source    ExternalVariables
+		SkipEmpty bool
+		Output    []byte
+	}{
+		{
+			Name: "Should write all variables",
+			Source: ExternalVariables{
+				"LOGS_LEVEL":  "WARN",
+				"DEBUG":       "false",
+				"APP_DB_NAME": "",
+				"APP_DB_USER": nil,
+			},
+			Output: []byte(`APP_DB_NAME=
+APP_DB_USER=
+DEBUG=false
+LOGS_LEVEL=WARN
+`),
+		},
+		{
+			Name: "Should skip variables without default values",
+			Source: ExternalVariables{
+				"LOGS_LEVEL":  "WARN",
+				"DEBUG":       "false",
+				"APP_DB_NAME": "",
+				"APP_DB_USER": nil,
+			},
+			SkipEmpty: true,
+			Output: []byte(`DEBUG=false
+LOGS_LEVEL=WARN
+`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+
+			err := WriteEnv(&buf, tt.Source, tt.SkipEmpty)
+
+			assert.NoError(t, err)
+			assert.Equal(t, string(tt.Output), buf.String())
+		})
+	}
+}